@@ -0,0 +1,254 @@
+package gitbackend
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// execBackend is the original implementation: every query forks a `git`
+// subprocess. It's kept around as a fallback for repositories the native
+// backend can't open.
+type execBackend struct {
+	repoPath string
+}
+
+func newExecBackend(repoPath string) *execBackend {
+	return &execBackend{repoPath: repoPath}
+}
+
+func (b *execBackend) ListBranches() ([]BranchRef, error) {
+	cmd := exec.Command("git", "branch", "-a",
+		"--format=%(refname:short)\t%(committerdate:iso8601)\t%(authorname)\t%(objectname)")
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []BranchRef
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) < 3 {
+			continue
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if strings.Contains(name, "HEAD") {
+			continue
+		}
+
+		commitDate, err := time.Parse("2006-01-02 15:04:05 -0700", strings.TrimSpace(parts[1]))
+		if err != nil {
+			continue
+		}
+
+		branch := BranchRef{
+			Name:       name,
+			IsRemote:   strings.HasPrefix(name, "origin/"),
+			LastCommit: commitDate,
+			Author:     strings.TrimSpace(parts[2]),
+		}
+		if len(parts) > 3 {
+			branch.CommitHash = strings.TrimSpace(parts[3])
+		}
+		branches = append(branches, branch)
+	}
+
+	return branches, scanner.Err()
+}
+
+func (b *execBackend) IsMerged(branch, base string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", branch, base)
+	cmd.Dir = b.repoPath
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, err
+}
+
+func (b *execBackend) BlameLine(file string, line int) (BlameResult, error) {
+	cmd := exec.Command("git", "blame", "-L", fmt.Sprintf("%d,%d", line, line), "--porcelain", file)
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return BlameResult{}, err
+	}
+
+	var result BlameResult
+	lines := strings.Split(string(output), "\n")
+	if len(lines) > 0 {
+		result.CommitHash = strings.Fields(lines[0])[0]
+	}
+	for _, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "committer-time "):
+			ts, err := strconv.ParseInt(strings.TrimPrefix(l, "committer-time "), 10, 64)
+			if err != nil {
+				return BlameResult{}, err
+			}
+			result.CommitterTime = time.Unix(ts, 0)
+		case strings.HasPrefix(l, "author "):
+			result.Author = strings.TrimPrefix(l, "author ")
+		}
+	}
+
+	if result.CommitterTime.IsZero() {
+		return BlameResult{}, fmt.Errorf("could not parse git blame output for %s:%d", file, line)
+	}
+
+	return result, nil
+}
+
+func (b *execBackend) HeadCommit() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (b *execBackend) ChangedFiles(fromRev, toRev string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", fromRev, toRev)
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, scanner.Err()
+}
+
+func (b *execBackend) BlobHash(file string) (string, error) {
+	cmd := exec.Command("git", "hash-object", file)
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func (b *execBackend) MergeBase(a, rev string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, rev)
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// commitLogFieldSep separates the header fields of each `git log` entry;
+// chosen because it can't appear in a commit message or author name.
+const commitLogFieldSep = "\x1f"
+
+func (b *execBackend) CommitLog() ([]CommitSummary, error) {
+	cmd := exec.Command("git", "log",
+		"--format=commit"+commitLogFieldSep+"%H"+commitLogFieldSep+"%an"+commitLogFieldSep+"%at",
+		"--numstat")
+	cmd.Dir = b.repoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []CommitSummary
+	var current *CommitSummary
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "commit"+commitLogFieldSep) {
+			if current != nil {
+				commits = append(commits, *current)
+			}
+
+			fields := strings.Split(line, commitLogFieldSep)
+			if len(fields) < 4 {
+				current = nil
+				continue
+			}
+
+			ts, err := strconv.ParseInt(fields[3], 10, 64)
+			if err != nil {
+				current = nil
+				continue
+			}
+
+			current = &CommitSummary{
+				Hash:       fields[1],
+				Author:     fields[2],
+				AuthorTime: time.Unix(ts, 0),
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		// Numstat line: additions<TAB>deletions<TAB>path (or "-" for binary).
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		additions, _ := strconv.Atoi(fields[0])
+		deletions, _ := strconv.Atoi(fields[1])
+		current.Files = append(current.Files, FileStat{
+			Path:      fields[2],
+			Additions: additions,
+			Deletions: deletions,
+		})
+	}
+
+	if current != nil {
+		commits = append(commits, *current)
+	}
+
+	return commits, scanner.Err()
+}
+
+func (b *execBackend) CommitTime(rev string) (time.Time, error) {
+	cmd := exec.Command("git", "show", "-s", "--format=%ct", rev)
+	cmd.Dir = b.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ts, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(ts, 0), nil
+}
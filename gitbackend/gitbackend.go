@@ -0,0 +1,88 @@
+// Package gitbackend abstracts repository access behind a small interface so
+// callers don't have to care whether answers come from a spawned `git`
+// process or from an in-memory object database walk.
+package gitbackend
+
+import "time"
+
+// BranchRef describes a single branch as reported by a GitBackend.
+type BranchRef struct {
+	Name       string
+	IsRemote   bool
+	LastCommit time.Time
+	Author     string
+	CommitHash string
+}
+
+// BlameResult is the information findTodoComments needs about the commit
+// that introduced a given line.
+type BlameResult struct {
+	CommitHash    string
+	Author        string
+	CommitterTime time.Time
+}
+
+// FileStat is one file's line-level churn within a single commit, as
+// reported by `git log --numstat`.
+type FileStat struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// CommitSummary is a single commit's metadata plus the files it touched,
+// used by the metrics package to compute repo-wide health signals without
+// re-walking history per signal.
+type CommitSummary struct {
+	Hash       string
+	Author     string
+	AuthorTime time.Time
+	Files      []FileStat
+}
+
+// GitBackend is implemented by anything that can answer branch and blame
+// queries for a repository. The exec-based backend shells out to the `git`
+// binary; the native backend reads the object database directly.
+type GitBackend interface {
+	// ListBranches returns every local and remote-tracking branch.
+	ListBranches() ([]BranchRef, error)
+
+	// IsMerged reports whether branch is reachable from base.
+	IsMerged(branch, base string) (bool, error)
+
+	// BlameLine resolves the commit that last touched file:line.
+	BlameLine(file string, line int) (BlameResult, error)
+
+	// HeadCommit returns the hash HEAD currently points at.
+	HeadCommit() (string, error)
+
+	// ChangedFiles returns the paths that differ between two commit-ish
+	// revisions, as `git diff --name-only` would report.
+	ChangedFiles(fromRev, toRev string) ([]string, error)
+
+	// BlobHash returns the git blob hash of file's current working-tree
+	// content (as `git hash-object` would compute it, not whatever's
+	// committed at HEAD), suitable as a cache key that changes whenever
+	// the file's on-disk content does, including uncommitted edits.
+	BlobHash(file string) (string, error)
+
+	// CommitTime resolves the committer time of a commit-ish revision.
+	CommitTime(rev string) (time.Time, error)
+
+	// MergeBase returns the best common ancestor of a and b.
+	MergeBase(a, b string) (string, error)
+
+	// CommitLog walks the full commit graph reachable from HEAD once,
+	// newest first, with per-file line churn for each commit.
+	CommitLog() ([]CommitSummary, error)
+}
+
+// New opens repoPath with the native backend, falling back to the exec
+// backend if the repository can't be opened as a plain object database
+// (bare worktrees, submodule quirks, etc).
+func New(repoPath string) GitBackend {
+	if nb, err := newNativeBackend(repoPath); err == nil {
+		return nb
+	}
+	return newExecBackend(repoPath)
+}
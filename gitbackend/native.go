@@ -0,0 +1,290 @@
+package gitbackend
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// nativeBackend answers branch and blame queries by walking the object
+// database in-process (via go-git), instead of forking `git` once per
+// query. BlameLine in particular amortizes the cost of a file's blame
+// across every matched line in it, so a scan with thousands of TODOs in
+// the same file pays for one walk rather than one process per line.
+type nativeBackend struct {
+	repo     *git.Repository
+	repoPath string
+
+	blameMu    sync.Mutex
+	blameCache map[string]*git.BlameResult
+}
+
+func newNativeBackend(repoPath string) (*nativeBackend, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return &nativeBackend{
+		repo:       repo,
+		repoPath:   repoPath,
+		blameCache: make(map[string]*git.BlameResult),
+	}, nil
+}
+
+func (b *nativeBackend) ListBranches() ([]BranchRef, error) {
+	var branches []BranchRef
+
+	refs, err := b.repo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refs.Close()
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name()
+		isRemote := name.IsRemote()
+		if !name.IsBranch() && !isRemote {
+			return nil
+		}
+
+		commit, err := b.repo.CommitObject(ref.Hash())
+		if err != nil {
+			// Tag or annotated ref pointing somewhere that isn't a commit;
+			// skip rather than fail the whole listing.
+			return nil
+		}
+
+		branches = append(branches, BranchRef{
+			Name:       shortRefName(name),
+			IsRemote:   isRemote,
+			LastCommit: commit.Committer.When,
+			Author:     commit.Author.Name,
+			CommitHash: commit.Hash.String(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return branches, nil
+}
+
+func (b *nativeBackend) IsMerged(branch, base string) (bool, error) {
+	branchCommit, err := b.resolveCommit(branch)
+	if err != nil {
+		return false, err
+	}
+	baseCommit, err := b.resolveCommit(base)
+	if err != nil {
+		return false, err
+	}
+
+	return branchCommit.IsAncestor(baseCommit)
+}
+
+func (b *nativeBackend) resolveCommit(ref string) (*object.Commit, error) {
+	hash, err := b.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("resolving %q: %w", ref, err)
+	}
+	return b.repo.CommitObject(*hash)
+}
+
+func (b *nativeBackend) BlameLine(file string, line int) (BlameResult, error) {
+	blame, err := b.fileBlame(file)
+	if err != nil {
+		return BlameResult{}, err
+	}
+
+	idx := line - 1
+	if idx < 0 || idx >= len(blame.Lines) {
+		return BlameResult{}, fmt.Errorf("line %d out of range for %s", line, file)
+	}
+
+	l := blame.Lines[idx]
+	return BlameResult{
+		CommitHash:    l.Hash.String(),
+		Author:        l.Author,
+		CommitterTime: l.Date,
+	}, nil
+}
+
+// fileBlame returns the cached HEAD blame for file, computing it once per
+// file per process lifetime.
+func (b *nativeBackend) fileBlame(file string) (*git.BlameResult, error) {
+	b.blameMu.Lock()
+	defer b.blameMu.Unlock()
+
+	if cached, ok := b.blameCache[file]; ok {
+		return cached, nil
+	}
+
+	head, err := b.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := b.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	blame, err := git.Blame(commit, file)
+	if err != nil {
+		return nil, err
+	}
+
+	b.blameCache[file] = blame
+	return blame, nil
+}
+
+func (b *nativeBackend) HeadCommit() (string, error) {
+	head, err := b.repo.Head()
+	if err != nil {
+		return "", err
+	}
+	return head.Hash().String(), nil
+}
+
+func (b *nativeBackend) ChangedFiles(fromRev, toRev string) ([]string, error) {
+	fromCommit, err := b.resolveCommit(fromRev)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := b.resolveCommit(toRev)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := fromTree.Diff(toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(changes))
+	var files []string
+	for _, change := range changes {
+		for _, path := range []string{change.From.Name, change.To.Name} {
+			if path != "" && !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+		}
+	}
+	return files, nil
+}
+
+// BlobHash hashes file's current on-disk content the same way `git
+// hash-object` would, matching execBackend.BlobHash: a working-tree
+// fingerprint, not the hash of whatever's committed at HEAD, so an
+// uncommitted edit always changes the result.
+func (b *nativeBackend) BlobHash(file string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(b.repoPath, file))
+	if err != nil {
+		return "", err
+	}
+	return plumbing.ComputeHash(plumbing.BlobObject, data).String(), nil
+}
+
+func (b *nativeBackend) CommitTime(rev string) (time.Time, error) {
+	commit, err := b.resolveCommit(rev)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return commit.Committer.When, nil
+}
+
+func (b *nativeBackend) MergeBase(a, rev string) (string, error) {
+	commitA, err := b.resolveCommit(a)
+	if err != nil {
+		return "", err
+	}
+	commitB, err := b.resolveCommit(rev)
+	if err != nil {
+		return "", err
+	}
+
+	bases, err := commitA.MergeBase(commitB)
+	if err != nil {
+		return "", err
+	}
+	if len(bases) == 0 {
+		return "", fmt.Errorf("no merge base between %q and %q", a, rev)
+	}
+	return bases[0].Hash.String(), nil
+}
+
+func (b *nativeBackend) CommitLog() ([]CommitSummary, error) {
+	head, err := b.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := b.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var commits []CommitSummary
+	err = iter.ForEach(func(c *object.Commit) error {
+		stats, err := c.Stats()
+		if err != nil {
+			// A commit with no parents (or other stat failure) still
+			// contributes author/time information.
+			commits = append(commits, CommitSummary{
+				Hash:       c.Hash.String(),
+				Author:     c.Author.Name,
+				AuthorTime: c.Author.When,
+			})
+			return nil
+		}
+
+		summary := CommitSummary{
+			Hash:       c.Hash.String(),
+			Author:     c.Author.Name,
+			AuthorTime: c.Author.When,
+			Files:      make([]FileStat, 0, len(stats)),
+		}
+		for _, s := range stats {
+			summary.Files = append(summary.Files, FileStat{
+				Path:      s.Name,
+				Additions: s.Addition,
+				Deletions: s.Deletion,
+			})
+		}
+		commits = append(commits, summary)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+func shortRefName(name plumbing.ReferenceName) string {
+	switch {
+	case name.IsBranch():
+		return name.Short()
+	case name.IsRemote():
+		return name.Short()
+	default:
+		return name.String()
+	}
+}
@@ -0,0 +1,130 @@
+package hosting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+type giteaProvider struct {
+	host, owner, repo string
+	token             string
+	client            *http.Client
+}
+
+func newGiteaProvider(host, owner, repo string) *giteaProvider {
+	return &giteaProvider{
+		host:   host,
+		owner:  owner,
+		repo:   repo,
+		token:  os.Getenv("GITEA_TOKEN"),
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *giteaProvider) Name() string { return "Gitea" }
+
+type giteaPR struct {
+	Number int                    `json:"number"`
+	Title  string                 `json:"title"`
+	Draft  bool                   `json:"draft"`
+	User   struct{ Login string } `json:"user"`
+	Head   struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (p *giteaProvider) ListOpenPullRequests() ([]PullRequest, error) {
+	apiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/%s/pulls?state=open&limit=100", p.host, p.owner, p.repo)
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "token "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitea API returned %s", resp.Status)
+	}
+
+	var raw []giteaPR
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(raw))
+	for _, r := range raw {
+		prs = append(prs, PullRequest{
+			Number:     r.Number,
+			Title:      r.Title,
+			HeadBranch: r.Head.Ref,
+			HeadSHA:    r.Head.SHA,
+			Author:     r.User.Login,
+			Draft:      r.Draft,
+			// Gitea's review API requires one call per PR to list reviews
+			// and tally approvals/rejections; not worth the request fan-out
+			// until a caller actually needs it.
+			ReviewState:  "unknown",
+			CIStatus:     p.commitStatus(r.Head.SHA),
+			CreatedAt:    r.CreatedAt,
+			LastActivity: r.UpdatedAt,
+		})
+	}
+
+	return prs, nil
+}
+
+// commitStatus fetches the combined commit status for sha and maps it to
+// the CIStatus convention ("success", "failure", "pending", "unknown").
+// Best-effort: any lookup failure degrades to "unknown" rather than failing
+// the whole listing.
+func (p *giteaProvider) commitStatus(sha string) string {
+	apiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/%s/commits/%s/status", p.host, p.owner, p.repo, sha)
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "unknown"
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "token "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "unknown"
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "unknown"
+	}
+
+	var combined struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&combined); err != nil {
+		return "unknown"
+	}
+
+	switch combined.State {
+	case "success":
+		return "success"
+	case "failure", "error":
+		return "failure"
+	case "pending":
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
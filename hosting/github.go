@@ -0,0 +1,208 @@
+package hosting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultGitHubAPIBase is the production GitHub API host. Tests override
+// githubAPIBase to point at a local fake server.
+const defaultGitHubAPIBase = "https://api.github.com"
+
+var githubAPIBase = defaultGitHubAPIBase
+
+type githubProvider struct {
+	owner, repo string
+	token       string
+	client      *http.Client
+}
+
+func newGitHubProvider(owner, repo string) *githubProvider {
+	return &githubProvider{
+		owner:  owner,
+		repo:   repo,
+		token:  githubToken(),
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *githubProvider) Name() string { return "GitHub" }
+
+type githubPR struct {
+	Number    int                    `json:"number"`
+	Title     string                 `json:"title"`
+	Draft     bool                   `json:"draft"`
+	User      struct{ Login string } `json:"user"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Head      struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	RequestedReviewers []struct{ Login string } `json:"requested_reviewers"`
+}
+
+func (p *githubProvider) ListOpenPullRequests() ([]PullRequest, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls?state=open&per_page=100", githubAPIBase, p.owner, p.repo)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("github API returned %s", resp.Status)
+	}
+
+	var raw []githubPR
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(raw))
+	for _, r := range raw {
+		reviewers := make([]string, 0, len(r.RequestedReviewers))
+		for _, rv := range r.RequestedReviewers {
+			reviewers = append(reviewers, rv.Login)
+		}
+
+		reviewState, ciStatus := p.prStatus(r.Number, r.Head.SHA)
+
+		prs = append(prs, PullRequest{
+			Number:       r.Number,
+			Title:        r.Title,
+			HeadBranch:   r.Head.Ref,
+			HeadSHA:      r.Head.SHA,
+			Author:       r.User.Login,
+			Draft:        r.Draft,
+			ReviewState:  reviewState,
+			CIStatus:     ciStatus,
+			Reviewers:    reviewers,
+			CreatedAt:    r.CreatedAt,
+			LastActivity: r.UpdatedAt,
+		})
+	}
+
+	return prs, nil
+}
+
+// prStatus fetches review decision and combined CI status for a pull
+// request. Best-effort: a failed lookup degrades to "unknown" rather than
+// failing the whole listing.
+func (p *githubProvider) prStatus(number int, sha string) (reviewState, ciStatus string) {
+	reviewState, ciStatus = p.reviewDecision(number), "unknown"
+
+	statusURL := fmt.Sprintf("%s/repos/%s/%s/commits/%s/status", githubAPIBase, p.owner, p.repo, sha)
+	req, err := http.NewRequest(http.MethodGet, statusURL, nil)
+	if err != nil {
+		return
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var combined struct {
+		State string `json:"state"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&combined) == nil && combined.State != "" {
+		ciStatus = combined.State
+	}
+
+	return reviewState, ciStatus
+}
+
+type githubReview struct {
+	User  struct{ Login string } `json:"user"`
+	State string                 `json:"state"`
+}
+
+// reviewDecision mirrors GitHub's own reviewDecision: the latest review
+// per user decides that user's vote, and any outstanding
+// "changes requested" vote blocks approval even if others have approved.
+func (p *githubProvider) reviewDecision(number int) string {
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d/reviews?per_page=100", githubAPIBase, p.owner, p.repo, number)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "unknown"
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "unknown"
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "unknown"
+	}
+
+	var reviews []githubReview
+	if err := json.NewDecoder(resp.Body).Decode(&reviews); err != nil {
+		return "unknown"
+	}
+	if len(reviews) == 0 {
+		return "no_review"
+	}
+
+	// Reviews come back in submission order; keep only the latest state
+	// per reviewer.
+	latestByUser := make(map[string]string, len(reviews))
+	for _, rv := range reviews {
+		if rv.State == "" || rv.State == "COMMENTED" {
+			continue
+		}
+		latestByUser[rv.User.Login] = rv.State
+	}
+
+	sawApproval := false
+	for _, state := range latestByUser {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return "changes_requested"
+		case "APPROVED":
+			sawApproval = true
+		}
+	}
+	if sawApproval {
+		return "approved"
+	}
+	return "pending"
+}
+
+// githubToken looks for a token the way GitHub's own tooling does: an env
+// var first, then whatever `gh` has cached.
+func githubToken() string {
+	if tok := os.Getenv("GITHUB_TOKEN"); tok != "" {
+		return tok
+	}
+	if tok := os.Getenv("GH_TOKEN"); tok != "" {
+		return tok
+	}
+
+	out, err := exec.Command("gh", "auth", "token").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,59 @@
+package hosting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestGitHubProvider(t *testing.T, reviews []githubReview) *githubProvider {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(reviews)
+	}))
+	t.Cleanup(srv.Close)
+
+	p := newGitHubProvider("owner", "repo")
+	p.client = srv.Client()
+	githubAPIBase = srv.URL
+	t.Cleanup(func() { githubAPIBase = defaultGitHubAPIBase })
+	return p
+}
+
+func TestReviewDecisionChangesRequestedWins(t *testing.T) {
+	p := newTestGitHubProvider(t, []githubReview{
+		{User: struct{ Login string }{"alice"}, State: "APPROVED"},
+		{User: struct{ Login string }{"bob"}, State: "CHANGES_REQUESTED"},
+	})
+	if got := p.reviewDecision(1); got != "changes_requested" {
+		t.Errorf("reviewDecision = %q, want changes_requested", got)
+	}
+}
+
+func TestReviewDecisionLatestPerUserWins(t *testing.T) {
+	p := newTestGitHubProvider(t, []githubReview{
+		{User: struct{ Login string }{"alice"}, State: "CHANGES_REQUESTED"},
+		{User: struct{ Login string }{"alice"}, State: "APPROVED"},
+	})
+	if got := p.reviewDecision(1); got != "approved" {
+		t.Errorf("reviewDecision = %q, want approved", got)
+	}
+}
+
+func TestReviewDecisionNoReviews(t *testing.T) {
+	p := newTestGitHubProvider(t, nil)
+	if got := p.reviewDecision(1); got != "no_review" {
+		t.Errorf("reviewDecision = %q, want no_review", got)
+	}
+}
+
+func TestReviewDecisionCommentsOnlyIsPending(t *testing.T) {
+	p := newTestGitHubProvider(t, []githubReview{
+		{User: struct{ Login string }{"alice"}, State: "COMMENTED"},
+	})
+	if got := p.reviewDecision(1); got != "pending" {
+		t.Errorf("reviewDecision = %q, want pending", got)
+	}
+}
@@ -0,0 +1,169 @@
+package hosting
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+type gitlabProvider struct {
+	host, owner, repo string
+	token             string
+	client            *http.Client
+}
+
+func newGitLabProvider(host, owner, repo string) *gitlabProvider {
+	return &gitlabProvider{
+		host:   host,
+		owner:  owner,
+		repo:   repo,
+		token:  gitlabToken(),
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *gitlabProvider) Name() string { return "GitLab" }
+
+type gitlabMR struct {
+	IID                 int                         `json:"iid"`
+	Title               string                      `json:"title"`
+	Draft               bool                        `json:"draft"`
+	Author              struct{ Username string }   `json:"author"`
+	SourceBranch        string                      `json:"source_branch"`
+	SHA                 string                      `json:"sha"`
+	CreatedAt           time.Time                   `json:"created_at"`
+	UpdatedAt           time.Time                   `json:"updated_at"`
+	Reviewers           []struct{ Username string } `json:"reviewers"`
+	DetailedMergeStatus string                      `json:"detailed_merge_status"`
+}
+
+func (p *gitlabProvider) ListOpenPullRequests() ([]PullRequest, error) {
+	project := url.QueryEscape(p.owner + "/" + p.repo)
+	apiURL := fmt.Sprintf("https://%s/api/v4/projects/%s/merge_requests?state=opened&per_page=100", p.host, project)
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitlab API returned %s", resp.Status)
+	}
+
+	var raw []gitlabMR
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(raw))
+	for _, r := range raw {
+		reviewers := make([]string, 0, len(r.Reviewers))
+		for _, rv := range r.Reviewers {
+			reviewers = append(reviewers, rv.Username)
+		}
+
+		prs = append(prs, PullRequest{
+			Number:     r.IID,
+			Title:      r.Title,
+			HeadBranch: r.SourceBranch,
+			HeadSHA:    r.SHA,
+			Author:     r.Author.Username,
+			Draft:      r.Draft,
+			// detailed_merge_status approximates review state from GitLab's
+			// mergeability verdict rather than walking actual approvals
+			// (that needs a separate, paginated approvals-per-MR call);
+			// good enough to bucket into no_review/pending/approved.
+			ReviewState:  mergeStatusToReviewState(r.DetailedMergeStatus),
+			CIStatus:     p.pipelineStatus(r.IID),
+			Reviewers:    reviewers,
+			CreatedAt:    r.CreatedAt,
+			LastActivity: r.UpdatedAt,
+		})
+	}
+
+	return prs, nil
+}
+
+func mergeStatusToReviewState(status string) string {
+	switch status {
+	case "not_approved":
+		return "no_review"
+	case "mergeable":
+		return "approved"
+	default:
+		return "pending"
+	}
+}
+
+// pipelineStatus fetches the most recent pipeline run for a merge request
+// and maps it to the CIStatus convention ("success", "failure", "pending",
+// "unknown"). Best-effort: any lookup failure degrades to "unknown" rather
+// than failing the whole listing.
+func (p *gitlabProvider) pipelineStatus(iid int) string {
+	project := url.QueryEscape(p.owner + "/" + p.repo)
+	apiURL := fmt.Sprintf("https://%s/api/v4/projects/%s/merge_requests/%d/pipelines", p.host, project, iid)
+
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "unknown"
+	}
+	if p.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "unknown"
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "unknown"
+	}
+
+	var pipelines []struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil || len(pipelines) == 0 {
+		return "unknown"
+	}
+
+	// Pipelines are returned newest first.
+	switch pipelines[0].Status {
+	case "success":
+		return "success"
+	case "failed":
+		return "failure"
+	case "running", "pending", "created", "waiting_for_resource", "preparing", "scheduled":
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
+
+func gitlabToken() string {
+	if tok := os.Getenv("GITLAB_TOKEN"); tok != "" {
+		return tok
+	}
+	if tok := os.Getenv("CI_JOB_TOKEN"); tok != "" {
+		return tok
+	}
+
+	out, err := exec.Command("glab", "auth", "token").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
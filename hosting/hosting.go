@@ -0,0 +1,96 @@
+// Package hosting talks to the Git hosting platform a repository's origin
+// remote points at, so analyzers can report the real state of a pull
+// request instead of guessing from local branch metadata alone.
+package hosting
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PullRequest is the hosting-platform-agnostic view of an open PR/MR.
+type PullRequest struct {
+	Number       int
+	Title        string
+	HeadBranch   string
+	HeadSHA      string
+	Author       string
+	Draft        bool
+	ReviewState  string // "approved", "changes_requested", "no_review", "pending"
+	CIStatus     string // "success", "failure", "pending", "unknown"
+	Reviewers    []string
+	CreatedAt    time.Time
+	LastActivity time.Time
+}
+
+// Provider is implemented by each supported hosting platform.
+type Provider interface {
+	// Name identifies the provider for display purposes, e.g. "GitHub".
+	Name() string
+
+	// ListOpenPullRequests returns every open PR/MR for the repository.
+	ListOpenPullRequests() ([]PullRequest, error)
+}
+
+// Detect inspects the `origin` remote of the repository at repoPath and
+// returns a Provider for it. It returns an error if the remote isn't
+// configured or doesn't match a known hosting platform.
+func Detect(repoPath string) (Provider, error) {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading origin remote: %w", err)
+	}
+
+	remoteURL := strings.TrimSpace(string(output))
+	owner, repo, host, err := parseRemote(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case host == "github.com":
+		return newGitHubProvider(owner, repo), nil
+	case host == "gitlab.com" || strings.Contains(host, "gitlab"):
+		return newGitLabProvider(host, owner, repo), nil
+	case strings.Contains(host, "gitea"):
+		return newGiteaProvider(host, owner, repo), nil
+	default:
+		return nil, fmt.Errorf("unrecognized hosting platform for remote %q", remoteURL)
+	}
+}
+
+// OriginOwnerAndRepo returns the owner/repo pair parsed from the repository
+// at repoPath's `origin` remote, regardless of which hosting platform it's
+// on.
+func OriginOwnerAndRepo(repoPath string) (owner, repo string, err error) {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("reading origin remote: %w", err)
+	}
+
+	owner, repo, _, err = parseRemote(strings.TrimSpace(string(output)))
+	return owner, repo, err
+}
+
+var (
+	sshRemoteRe   = regexp.MustCompile(`^git@([^:]+):([^/]+)/(.+?)(\.git)?$`)
+	httpsRemoteRe = regexp.MustCompile(`^https?://([^/]+)/([^/]+)/(.+?)(\.git)?$`)
+)
+
+// parseRemote extracts host/owner/repo from an SSH or HTTPS remote URL.
+func parseRemote(remoteURL string) (owner, repo, host string, err error) {
+	if m := sshRemoteRe.FindStringSubmatch(remoteURL); m != nil {
+		return m[2], m[3], m[1], nil
+	}
+	if m := httpsRemoteRe.FindStringSubmatch(remoteURL); m != nil {
+		return m[2], m[3], m[1], nil
+	}
+	return "", "", "", fmt.Errorf("could not parse remote URL %q", remoteURL)
+}
@@ -1,28 +1,45 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
+
+	"repo-analyze/gitbackend"
+	"repo-analyze/hosting"
+	"repo-analyze/metrics"
+	"repo-analyze/report"
+	"repo-analyze/todocache"
+	"repo-analyze/todoparse"
 )
 
+// todoCacheFile is the incremental-scan cache written alongside the
+// repository being analyzed.
+const todoCacheFile = ".repo-analyze-cache.json"
+
 type Config struct {
 	RepoPath      string
 	StaleDays     int
 	TodoDays      int
+	Format        string
+	Since         string
 	ShowHelp      bool
 	CheckBranches bool
 	CheckPRs      bool
 	CheckTodos    bool
+	CheckMetrics  bool
+
+	// JiraURL and GitHubIssues enable looking up whether a TODO's annotated
+	// ticket is already closed, so the report can flag stale-but-resolved
+	// comments separately from stale-and-still-open ones.
+	JiraURL      string
+	GitHubIssues bool
 }
 
 type BranchInfo struct {
@@ -34,12 +51,20 @@ type BranchInfo struct {
 }
 
 type TodoItem struct {
-	File    string
-	Line    int
-	Content string
-	Type    string // TODO, FIXME, etc.
-	Age     time.Time
-	DaysOld int
+	File       string
+	Line       int
+	Content    string
+	Type       string // TODO, FIXME, etc.
+	Age        time.Time
+	DaysOld    int
+	CommitHash string
+
+	// Assignee is the explicit TODO(author) annotation if present,
+	// otherwise the blame author.
+	Assignee string
+	DueDate  time.Time
+	Ticket   string
+	Tracker  string
 }
 
 func main() {
@@ -55,7 +80,14 @@ func main() {
 		log.Fatalf("Error: %v", err)
 	}
 
-	fmt.Printf("🔍 Analyzing repository: %s\n\n", config.RepoPath)
+	writer, err := report.NewWriter(config.Format)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if config.Format == "" || config.Format == "text" {
+		fmt.Printf("🔍 Analyzing repository: %s\n\n", config.RepoPath)
+	}
 
 	// Change to repository directory
 	originalDir, err := os.Getwd()
@@ -68,17 +100,46 @@ func main() {
 	}
 	defer os.Chdir(originalDir)
 
-	// Run analyses based on configuration
+	backend := gitbackend.New(".")
+
+	rep := report.Report{
+		RepoPath:           config.RepoPath,
+		StaleDaysThreshold: config.StaleDays,
+		TodoDaysThreshold:  config.TodoDays,
+	}
+
 	if config.CheckBranches {
-		analyzeStaleBranches(config.StaleDays)
+		rep.StaleBranches, err = gatherStaleBranches(backend, config.StaleDays)
+		if err != nil {
+			log.Printf("Error analyzing branches: %v", err)
+		}
 	}
 
 	if config.CheckPRs {
-		analyzeUnmergedPRs()
+		rep.UnmergedPRs, err = gatherUnmergedPRs(backend, config.StaleDays)
+		if err != nil {
+			log.Printf("Error analyzing unmerged PRs: %v", err)
+		}
 	}
 
 	if config.CheckTodos {
-		analyzeTodoComments(config.TodoDays)
+		rep.Todos, err = gatherTodoComments(backend, config.TodoDays, config.Since, config.JiraURL, config.GitHubIssues)
+		if err != nil {
+			log.Printf("Error analyzing TODO comments: %v", err)
+		}
+	}
+
+	if config.CheckMetrics {
+		m, err := metrics.Compute(backend, metrics.DefaultOptions())
+		if err != nil {
+			log.Printf("Error computing repo health metrics: %v", err)
+		} else {
+			rep.Metrics = &m
+		}
+	}
+
+	if err := writer.Write(os.Stdout, rep); err != nil {
+		log.Fatalf("Error writing report: %v", err)
 	}
 }
 
@@ -88,10 +149,15 @@ func parseFlags() Config {
 	flag.StringVar(&config.RepoPath, "path", ".", "Path to git repository")
 	flag.IntVar(&config.StaleDays, "stale-days", 30, "Days to consider a branch stale")
 	flag.IntVar(&config.TodoDays, "todo-days", 90, "Days to consider TODO/FIXME comments old")
+	flag.StringVar(&config.Format, "format", "text", "Output format: text|json|sarif|junit|html")
+	flag.StringVar(&config.Since, "since", "", "Only report TODOs introduced since this ref (presubmit mode)")
 	flag.BoolVar(&config.ShowHelp, "help", false, "Show help message")
 	flag.BoolVar(&config.CheckBranches, "branches", true, "Check for stale branches")
 	flag.BoolVar(&config.CheckPRs, "prs", true, "Check for unmerged PRs")
 	flag.BoolVar(&config.CheckTodos, "todos", true, "Check for old TODO/FIXME comments")
+	flag.BoolVar(&config.CheckMetrics, "metrics", false, "Compute repo health metrics (bus factor, churn, ownership decay, branch lifetime)")
+	flag.StringVar(&config.JiraURL, "jira-url", "", "JIRA base URL, used to check whether TODO(JIRA-123)-style tickets are resolved")
+	flag.BoolVar(&config.GitHubIssues, "github-issues", false, "Check whether TODO(#123)-style GitHub issues are closed")
 
 	flag.Parse()
 
@@ -117,6 +183,10 @@ func printUsage() {
 	fmt.Println("Examples:")
 	fmt.Println("  git-analyzer -path=/path/to/repo -stale-days=14")
 	fmt.Println("  git-analyzer -branches=false -todos=true -todo-days=60")
+	fmt.Println("  git-analyzer -format=sarif -todos=true > todos.sarif")
+	fmt.Println("  git-analyzer -todos=true -since=origin/main")
+	fmt.Println("  git-analyzer -metrics -branches=false -prs=false -todos=false")
+	fmt.Println("  git-analyzer -todos=true -github-issues -jira-url=https://yourcompany.atlassian.net")
 }
 
 func validateGitRepo(path string) error {
@@ -127,88 +197,49 @@ func validateGitRepo(path string) error {
 	return nil
 }
 
-func analyzeStaleBranches(staleDays int) {
-	fmt.Println("📊 Analyzing Stale Branches")
-	fmt.Println("===========================")
-
-	branches, err := getStaleBranches(staleDays)
+// gatherStaleBranches finds stale branches and converts them into report
+// findings for whichever output format the caller selected.
+func gatherStaleBranches(backend gitbackend.GitBackend, staleDays int) ([]report.BranchFinding, error) {
+	branches, err := getStaleBranches(backend, staleDays)
 	if err != nil {
-		log.Printf("Error analyzing branches: %v", err)
-		return
-	}
-
-	if len(branches) == 0 {
-		fmt.Printf("✅ No stale branches found (older than %d days)\n\n", staleDays)
-		return
+		return nil, err
 	}
 
-	// Sort by days stale (most stale first)
 	sort.Slice(branches, func(i, j int) bool {
 		return branches[i].DaysStale > branches[j].DaysStale
 	})
 
-	fmt.Printf("Found %d stale branches:\n\n", len(branches))
-
+	findings := make([]report.BranchFinding, 0, len(branches))
 	for _, branch := range branches {
-		branchType := "local"
-		if branch.IsRemote {
-			branchType = "remote"
-		}
-
-		fmt.Printf("🔸 %s (%s)\n", branch.Name, branchType)
-		fmt.Printf("   Last commit: %s (%d days ago)\n",
-			branch.LastCommit.Format("2006-01-02"), branch.DaysStale)
-		fmt.Printf("   Author: %s\n\n", branch.Author)
+		findings = append(findings, report.BranchFinding{
+			Name:       branch.Name,
+			IsRemote:   branch.IsRemote,
+			LastCommit: branch.LastCommit,
+			Author:     branch.Author,
+			DaysStale:  branch.DaysStale,
+		})
 	}
+
+	return findings, nil
 }
 
-func getStaleBranches(staleDays int) ([]BranchInfo, error) {
+func getStaleBranches(backend gitbackend.GitBackend, staleDays int) ([]BranchInfo, error) {
 	var branches []BranchInfo
 	cutoffDate := time.Now().AddDate(0, 0, -staleDays)
 
-	// Get all branches (local and remote)
-	cmd := exec.Command("git", "branch", "-a", "--format=%(refname:short)%09%(committerdate:iso8601)%09%(authorname)")
-	output, err := cmd.Output()
+	refs, err := backend.ListBranches()
 	if err != nil {
 		return nil, err
 	}
 
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		parts := strings.Split(line, "\t")
-		if len(parts) < 3 {
-			continue
-		}
-
-		branchName := strings.TrimSpace(parts[0])
-		commitDateStr := strings.TrimSpace(parts[1])
-		authorName := strings.TrimSpace(parts[2])
-
-		// Skip HEAD references
-		if strings.Contains(branchName, "HEAD") {
-			continue
-		}
-
-		commitDate, err := time.Parse("2006-01-02 15:04:05 -0700", commitDateStr)
-		if err != nil {
-			continue
-		}
-
-		if commitDate.Before(cutoffDate) {
-			daysStale := int(time.Since(commitDate).Hours() / 24)
-			isRemote := strings.HasPrefix(branchName, "origin/")
-
+	for _, ref := range refs {
+		if ref.LastCommit.Before(cutoffDate) {
 			branches = append(branches, BranchInfo{
-				Name:       branchName,
-				LastCommit: commitDate,
-				Author:     authorName,
-				DaysStale:  daysStale,
-				IsRemote:   isRemote,
+				Name:       ref.Name,
+				LastCommit: ref.LastCommit,
+				Author:     ref.Author,
+				DaysStale:  int(time.Since(ref.LastCommit).Hours() / 24),
+				IsRemote:   ref.IsRemote,
 			})
 		}
 	}
@@ -216,97 +247,138 @@ func getStaleBranches(staleDays int) ([]BranchInfo, error) {
 	return branches, nil
 }
 
-func analyzeUnmergedPRs() {
-	fmt.Println("🔀 Analyzing Unmerged Pull Requests")
-	fmt.Println("===================================")
+// gatherUnmergedPRs reports real open PRs/MRs when the origin remote maps to
+// a known hosting platform, falling back to the unmerged-branch heuristic
+// otherwise. Findings are matched up to local branches by head SHA, and
+// flagged for the conditions a reviewer actually cares about: no review in
+// staleDays, failing checks, and changes requested (awaiting the author).
+func gatherUnmergedPRs(backend gitbackend.GitBackend, staleDays int) ([]report.PRFinding, error) {
+	provider, err := hosting.Detect(".")
+	if err != nil {
+		return gatherUnmergedBranchesHeuristic(backend)
+	}
 
-	// This is a simplified implementation that checks for branches that might be PRs
-	// In a real implementation, you'd integrate with GitHub/GitLab APIs
+	prs, err := provider.ListOpenPullRequests()
+	if err != nil {
+		return nil, fmt.Errorf("fetching open PRs from %s: %w", provider.Name(), err)
+	}
 
-	unmergedBranches, err := getUnmergedBranches()
+	localBranchBySHA, err := localBranchesBySHA(backend)
 	if err != nil {
-		log.Printf("Error analyzing unmerged branches: %v", err)
-		return
+		return nil, err
 	}
 
-	if len(unmergedBranches) == 0 {
-		fmt.Println("✅ No potential unmerged PR branches found\n")
-		return
+	findings := make([]report.PRFinding, 0, len(prs))
+	for _, pr := range prs {
+		ageDays := int(time.Since(pr.CreatedAt).Hours() / 24)
+		noReview := pr.ReviewState == "no_review" || pr.ReviewState == "pending"
+
+		findings = append(findings, report.PRFinding{
+			Number:                 pr.Number,
+			Title:                  pr.Title,
+			Author:                 pr.Author,
+			HeadBranch:             pr.HeadBranch,
+			HeadSHA:                pr.HeadSHA,
+			Draft:                  pr.Draft,
+			ReviewState:            pr.ReviewState,
+			CIStatus:               pr.CIStatus,
+			DaysSinceActivity:      int(time.Since(pr.LastActivity).Hours() / 24),
+			LocalBranch:            localBranchBySHA[pr.HeadSHA],
+			NoReviewStale:          noReview && ageDays > staleDays,
+			FailingChecks:          pr.CIStatus == "failure",
+			AwaitingAuthorResponse: pr.ReviewState == "changes_requested" || pr.CIStatus == "failure",
+		})
 	}
 
-	fmt.Printf("Found %d potential unmerged PR branches:\n\n", len(unmergedBranches))
+	return findings, nil
+}
 
+// localBranchesBySHA maps each local branch's tip commit hash to its name,
+// so a PR can be matched to the local branch a reviewer already has checked
+// out, if any.
+func localBranchesBySHA(backend gitbackend.GitBackend) (map[string]string, error) {
+	refs, err := backend.ListBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	bySHA := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		if !ref.IsRemote {
+			bySHA[ref.CommitHash] = ref.Name
+		}
+	}
+	return bySHA, nil
+}
+
+// gatherUnmergedBranchesHeuristic is the previous, hosting-API-free
+// behavior: it infers "potential PR branches" from unmerged remote
+// branches alone. Used as a fallback for repos without a recognized
+// hosting remote.
+func gatherUnmergedBranchesHeuristic(backend gitbackend.GitBackend) ([]report.PRFinding, error) {
+	unmergedBranches, err := getUnmergedBranches(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]report.PRFinding, 0, len(unmergedBranches))
 	for _, branch := range unmergedBranches {
-		fmt.Printf("🔸 %s\n", branch.Name)
-		fmt.Printf("   Last commit: %s (%d days ago)\n",
-			branch.LastCommit.Format("2006-01-02"), branch.DaysStale)
-		fmt.Printf("   Author: %s\n\n", branch.Author)
+		findings = append(findings, report.PRFinding{
+			Title:             branch.Name,
+			Author:            branch.Author,
+			HeadBranch:        branch.Name,
+			ReviewState:       "unknown",
+			CIStatus:          "unknown",
+			DaysSinceActivity: branch.DaysStale,
+		})
 	}
 
-	fmt.Println("💡 Note: For complete PR analysis, integrate with your Git hosting platform's API")
-	fmt.Println()
+	return findings, nil
 }
 
-func getUnmergedBranches() ([]BranchInfo, error) {
+func getUnmergedBranches(backend gitbackend.GitBackend) ([]BranchInfo, error) {
 	var branches []BranchInfo
 
-	// Get remote branches that haven't been merged to main/master
+	// Find a main branch to diff against
 	mainBranches := []string{"main", "master", "develop"}
 	var mainBranch string
 
-	for _, branch := range mainBranches {
-		cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
-		if cmd.Run() == nil {
-			mainBranch = branch
-			break
-		}
-	}
-
-	if mainBranch == "" {
-		return branches, fmt.Errorf("no main branch found (main, master, or develop)")
-	}
-
-	// Get branches not merged into main
-	cmd := exec.Command("git", "branch", "-r", "--no-merged", mainBranch,
-		"--format=%(refname:short)%09%(committerdate:iso8601)%09%(authorname)")
-	output, err := cmd.Output()
+	refs, err := backend.ListBranches()
 	if err != nil {
 		return nil, err
 	}
 
-	scanner := bufio.NewScanner(strings.NewReader(string(output)))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+	for _, candidate := range mainBranches {
+		for _, ref := range refs {
+			if ref.Name == candidate {
+				mainBranch = candidate
+				break
+			}
 		}
-
-		parts := strings.Split(line, "\t")
-		if len(parts) < 3 {
-			continue
+		if mainBranch != "" {
+			break
 		}
+	}
 
-		branchName := strings.TrimSpace(parts[0])
-		commitDateStr := strings.TrimSpace(parts[1])
-		authorName := strings.TrimSpace(parts[2])
+	if mainBranch == "" {
+		return branches, fmt.Errorf("no main branch found (main, master, or develop)")
+	}
 
-		// Skip HEAD references
-		if strings.Contains(branchName, "HEAD") {
+	for _, ref := range refs {
+		if !ref.IsRemote {
 			continue
 		}
 
-		commitDate, err := time.Parse("2006-01-02 15:04:05 -0700", commitDateStr)
-		if err != nil {
+		merged, err := backend.IsMerged(ref.Name, mainBranch)
+		if err != nil || merged {
 			continue
 		}
 
-		daysOld := int(time.Since(commitDate).Hours() / 24)
-
 		branches = append(branches, BranchInfo{
-			Name:       branchName,
-			LastCommit: commitDate,
-			Author:     authorName,
-			DaysStale:  daysOld,
+			Name:       ref.Name,
+			LastCommit: ref.LastCommit,
+			Author:     ref.Author,
+			DaysStale:  int(time.Since(ref.LastCommit).Hours() / 24),
 			IsRemote:   true,
 		})
 	}
@@ -314,54 +386,86 @@ func getUnmergedBranches() ([]BranchInfo, error) {
 	return branches, nil
 }
 
-func analyzeTodoComments(todoDays int) {
-	fmt.Println("📝 Analyzing TODO/FIXME Comments")
-	fmt.Println("================================")
-
-	todos, err := findTodoComments(todoDays)
+// gatherTodoComments finds old TODO/FIXME/XXX/HACK comments and converts
+// them into report findings.
+func gatherTodoComments(backend gitbackend.GitBackend, todoDays int, since, jiraURL string, checkGitHubIssues bool) ([]report.TodoFinding, error) {
+	todos, err := findTodoComments(backend, todoDays, since)
 	if err != nil {
-		log.Printf("Error analyzing TODO comments: %v", err)
-		return
-	}
-
-	if len(todos) == 0 {
-		fmt.Printf("✅ No old TODO/FIXME comments found (older than %d days)\n\n", todoDays)
-		return
+		return nil, err
 	}
 
-	// Group by type and sort by age
 	sort.Slice(todos, func(i, j int) bool {
 		return todos[i].DaysOld > todos[j].DaysOld
 	})
 
-	todoCount := 0
-	fixmeCount := 0
+	findings := make([]report.TodoFinding, 0, len(todos))
 	for _, todo := range todos {
-		if strings.ToUpper(todo.Type) == "TODO" {
-			todoCount++
-		} else if strings.ToUpper(todo.Type) == "FIXME" {
-			fixmeCount++
+		findings = append(findings, report.TodoFinding{
+			File:         todo.File,
+			Line:         todo.Line,
+			Type:         todo.Type,
+			Content:      strings.TrimSpace(todo.Content),
+			DaysOld:      todo.DaysOld,
+			CommitHash:   todo.CommitHash,
+			Assignee:     todo.Assignee,
+			DueDate:      todo.DueDate,
+			Ticket:       todo.Ticket,
+			Tracker:      todo.Tracker,
+			TicketClosed: checkTicketClosed(todo, jiraURL, checkGitHubIssues),
+		})
+	}
+
+	return findings, nil
+}
+
+// findTodoComments walks the tree looking for TODO/FIXME/XXX/HACK comments.
+// Blame results are cached on disk in todoCacheFile, keyed by (file path,
+// working-tree content hash); a file whose content hasn't changed since the
+// last run, and wasn't touched between the cached HEAD and the current one,
+// is reused without re-blaming a single line. The content hash is computed
+// from the bytes actually scanned, not a committed blob hash, so uncommitted
+// edits always invalidate the cache.
+//
+// If since is set, todoDays is ignored and only lines whose blame commit is
+// newer than since's commit are reported - the presubmit-style mode where
+// authors only see the TODOs they just added.
+func findTodoComments(backend gitbackend.GitBackend, todoDays int, since string) ([]TodoItem, error) {
+	cutoffDate := time.Now().AddDate(0, 0, -todoDays)
+
+	var sinceTime time.Time
+	if since != "" {
+		t, err := backend.CommitTime(since)
+		if err != nil {
+			return nil, fmt.Errorf("resolving -since %q: %w", since, err)
 		}
+		sinceTime = t
 	}
 
-	fmt.Printf("Found %d old comments (%d TODOs, %d FIXMEs):\n\n",
-		len(todos), todoCount, fixmeCount)
+	cache, err := todocache.Load(todoCacheFile)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, todo := range todos {
-		fmt.Printf("🔸 %s (%d days old)\n", todo.Type, todo.DaysOld)
-		fmt.Printf("   File: %s:%d\n", todo.File, todo.Line)
-		fmt.Printf("   Content: %s\n\n", strings.TrimSpace(todo.Content))
+	headCommit, err := backend.HeadCommit()
+	if err != nil {
+		return nil, err
 	}
-}
 
-func findTodoComments(todoDays int) ([]TodoItem, error) {
-	var todos []TodoItem
-	cutoffDate := time.Now().AddDate(0, 0, -todoDays)
+	var changed map[string]bool
+	if cache.HeadCommit != "" && cache.HeadCommit != headCommit {
+		changedFiles, err := backend.ChangedFiles(cache.HeadCommit, headCommit)
+		if err != nil {
+			return nil, err
+		}
+		changed = make(map[string]bool, len(changedFiles))
+		for _, f := range changedFiles {
+			changed[f] = true
+		}
+	}
 
-	// Regular expression to match TODO/FIXME comments
-	todoRegex := regexp.MustCompile(`(?i)(TODO|FIXME|XXX|HACK)\s*[:\-]?\s*(.*)`)
+	var todos []TodoItem
 
-	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -371,52 +475,154 @@ func findTodoComments(todoDays int) ([]TodoItem, error) {
 			return nil
 		}
 
-		file, err := os.Open(path)
+		// Fingerprint the file by its actual working-tree content, not a
+		// committed blob: a tracked file with uncommitted edits must get a
+		// fresh cache key on every edit, not just on commit.
+		data, err := os.ReadFile(path)
 		if err != nil {
 			return err
 		}
-		defer file.Close()
-
-		scanner := bufio.NewScanner(file)
-		lineNum := 0
-
-		for scanner.Scan() {
-			lineNum++
-			line := scanner.Text()
-
-			if matches := todoRegex.FindStringSubmatch(line); matches != nil {
-				todoType := strings.ToUpper(matches[1])
-				content := matches[2]
-				if content == "" {
-					content = line
-				}
-
-				// Get the age of this file/line using git blame
-				age, err := getLineAge(path, lineNum)
-				if err != nil {
-					// If we can't get the age, assume it's old
-					age = cutoffDate.AddDate(0, 0, -1)
-				}
-
-				if age.Before(cutoffDate) {
-					daysOld := int(time.Since(age).Hours() / 24)
-
-					todos = append(todos, TodoItem{
-						File:    path,
-						Line:    lineNum,
-						Content: content,
-						Type:    todoType,
-						Age:     age,
-						DaysOld: daysOld,
-					})
-				}
+		contentHash := todocache.HashContent(data)
+
+		var records []todocache.Record
+		if !changed[path] {
+			if entry, ok := cache.Lookup(path, contentHash); ok {
+				records = entry.Records
+			}
+		}
+
+		if records == nil {
+			records, err = scanFileForTodos(backend, cache, path, data)
+			if err != nil {
+				return err
 			}
+			cache.Store(path, contentHash, records)
+		}
+
+		for _, rec := range records {
+			var include bool
+			if since != "" {
+				include = rec.CommitterTime.After(sinceTime)
+			} else {
+				include = rec.CommitterTime.Before(cutoffDate)
+			}
+			if !include {
+				continue
+			}
+
+			todos = append(todos, TodoItem{
+				File:       path,
+				Line:       rec.Line,
+				Content:    rec.Content,
+				Type:       rec.Type,
+				Age:        rec.CommitterTime,
+				DaysOld:    int(time.Since(rec.CommitterTime).Hours() / 24),
+				CommitHash: rec.CommitHash,
+				Assignee:   rec.Author,
+				DueDate:    rec.DueDate,
+				Ticket:     rec.Ticket,
+				Tracker:    rec.Tracker,
+			})
 		}
 
-		return scanner.Err()
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return todos, err
+	cache.HeadCommit = headCommit
+	if err := cache.Save(todoCacheFile); err != nil {
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// scanFileForTodos matches TODO-style comments in a single file and
+// resolves their blame via backend, one file-wide blame rather than one
+// process per matched line. For a comment whose content hash matches one
+// already cached for this file, the previously-resolved blame is reused
+// instead of re-blamed, so editing one line of a file doesn't force
+// re-blaming every other TODO in it. data is the file's already-read
+// working-tree content, so callers that fingerprinted it for the cache
+// don't pay for a second read.
+func scanFileForTodos(backend gitbackend.GitBackend, cache *todocache.Cache, path string, data []byte) ([]todocache.Record, error) {
+	comments, err := todoparse.ExtractComments(path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []todocache.Record
+	for _, comment := range comments {
+		marker, ok := todoparse.ParseMarker(comment.Text)
+		if !ok {
+			continue
+		}
+
+		lineHash := todocache.HashLine(comment.Text)
+
+		var blame gitbackend.BlameResult
+		if rec, ok := cache.LookupLine(path, lineHash); ok {
+			blame = gitbackend.BlameResult{CommitHash: rec.CommitHash, Author: rec.Author, CommitterTime: rec.CommitterTime}
+		} else {
+			// If blame fails, leave CommitterTime zero; callers treat that
+			// as "assume old" under a days-based cutoff and "not new"
+			// under -since.
+			blame, _ = backend.BlameLine(path, comment.Line)
+		}
+
+		author := marker.Author
+		if author == "" {
+			author = blame.Author
+		}
+
+		records = append(records, todocache.Record{
+			Line:          comment.Line,
+			LineHash:      lineHash,
+			Type:          marker.Type,
+			Content:       marker.Message,
+			CommitterTime: blame.CommitterTime,
+			CommitHash:    blame.CommitHash,
+			Author:        author,
+			DueDate:       marker.DueDate,
+			Ticket:        marker.Ticket,
+			Tracker:       marker.Tracker,
+		})
+	}
+
+	return records, nil
+}
+
+// checkTicketClosed looks up whether a TODO's referenced ticket is already
+// closed, when the corresponding tracker flag was given. Lookup failures
+// are swallowed: a best-effort annotation isn't worth failing the scan
+// over.
+func checkTicketClosed(todo TodoItem, jiraURL string, checkGitHubIssues bool) bool {
+	if todo.Ticket == "" {
+		return false
+	}
+
+	switch todo.Tracker {
+	case "jira":
+		if jiraURL == "" {
+			return false
+		}
+		closed, err := todoparse.JiraIssueClosed(jiraURL, todo.Ticket)
+		return err == nil && closed
+	case "github":
+		if !checkGitHubIssues {
+			return false
+		}
+		owner, repo, err := hosting.OriginOwnerAndRepo(".")
+		if err != nil {
+			return false
+		}
+		closed, err := todoparse.GitHubIssueClosed(owner, repo, todo.Ticket)
+		return err == nil && closed
+	default:
+		return false
+	}
 }
 
 func shouldSkipFile(path string) bool {
@@ -450,25 +656,3 @@ func shouldSkipFile(path string) bool {
 
 	return false
 }
-
-func getLineAge(file string, lineNum int) (time.Time, error) {
-	cmd := exec.Command("git", "blame", "-L", fmt.Sprintf("%d,%d", lineNum, lineNum), "--porcelain", file)
-	output, err := cmd.Output()
-	if err != nil {
-		return time.Time{}, err
-	}
-
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "committer-time ") {
-			timestampStr := strings.TrimPrefix(line, "committer-time ")
-			timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
-			if err != nil {
-				return time.Time{}, err
-			}
-			return time.Unix(timestamp, 0), nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("could not parse git blame output")
-}
@@ -0,0 +1,27 @@
+package metrics
+
+import "sort"
+
+// gini computes the Gini coefficient of a set of non-negative values,
+// 0 meaning perfectly even distribution and approaching 1 meaning
+// everything is concentrated in one value.
+func gini(values []int) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	return (2*weighted)/(float64(n)*sum) - float64(n+1)/float64(n)
+}
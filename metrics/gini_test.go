@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGiniEvenDistributionIsZero(t *testing.T) {
+	if g := gini([]int{5, 5, 5}); math.Abs(g) > 1e-9 {
+		t.Errorf("gini = %v, want 0", g)
+	}
+}
+
+func TestGiniMaximallyConcentrated(t *testing.T) {
+	got := gini([]int{0, 0, 10})
+	want := 2.0 / 3.0 // (n-1)/n for all mass on one value, n=3
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("gini = %v, want %v", got, want)
+	}
+}
+
+func TestGiniEmptyIsZero(t *testing.T) {
+	if g := gini(nil); g != 0 {
+		t.Errorf("gini(nil) = %v, want 0", g)
+	}
+}
+
+func TestGiniAllZeroIsZero(t *testing.T) {
+	if g := gini([]int{0, 0, 0}); g != 0 {
+		t.Errorf("gini(all zero) = %v, want 0", g)
+	}
+}
@@ -0,0 +1,278 @@
+// Package metrics computes repo-wide health signals from a single walk of
+// the commit graph: bus-factor per directory, churn hotspots, ownership
+// decay, and average branch lifetime. It complements the stale-branch and
+// TODO checks, which only look at point-in-time state.
+package metrics
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"repo-analyze/gitbackend"
+)
+
+// DirectoryBusFactor flags a directory whose last-touch authorship is
+// concentrated in few people. A high Gini coefficient means most files were
+// last touched by the same one or two authors.
+type DirectoryBusFactor struct {
+	Directory   string
+	Gini        float64
+	TopAuthor   string
+	AuthorCount int
+	FileCount   int
+}
+
+// ChurnHotspot is a file with unusually many commits in the recent window.
+type ChurnHotspot struct {
+	Path            string
+	CommitsInWindow int
+}
+
+// OwnershipDecay flags a file whose most frequent author hasn't committed
+// to it in a long time - a sign the original owner has moved on.
+type OwnershipDecay struct {
+	Path                     string
+	TopAuthor                string
+	DaysSinceTopAuthorCommit int
+}
+
+// BranchLifetime is how long a branch lived between diverging from the main
+// branch and its last commit.
+type BranchLifetime struct {
+	Branch string
+	Days   int
+}
+
+// Report is the full set of computed health signals for one run.
+type Report struct {
+	BusFactor                 []DirectoryBusFactor
+	ChurnHotspots             []ChurnHotspot
+	OwnershipDecay            []OwnershipDecay
+	BranchLifetimes           []BranchLifetime
+	AverageBranchLifetimeDays float64
+}
+
+// Options bounds the windows used for churn and ownership-decay detection.
+type Options struct {
+	ChurnWindowDays    int
+	DecayThresholdDays int
+	TopN               int // cap on how many hotspots/decayed files to report
+}
+
+// DefaultOptions mirrors the thresholds the rest of the tool defaults to.
+func DefaultOptions() Options {
+	return Options{ChurnWindowDays: 30, DecayThresholdDays: 180, TopN: 20}
+}
+
+// Compute walks backend's commit graph once and derives every signal in
+// Report from that single pass.
+func Compute(backend gitbackend.GitBackend, opts Options) (Report, error) {
+	commits, err := backend.CommitLog()
+	if err != nil {
+		return Report{}, err
+	}
+
+	churnCutoff := time.Now().AddDate(0, 0, -opts.ChurnWindowDays)
+
+	lastTouchAuthor := make(map[string]string) // file -> author of most recent commit
+	seenFile := make(map[string]bool)
+	authorCommitsPerFile := make(map[string]map[string]int)        // file -> author -> commits
+	authorLastTimePerFile := make(map[string]map[string]time.Time) // file -> author -> most recent commit time
+	churnCount := make(map[string]int)
+
+	// commits is newest-first, so the first time we see a file its author
+	// is the last-touch author and its per-author times are already the
+	// most recent for that author.
+	for _, c := range commits {
+		for _, f := range c.Files {
+			if !seenFile[f.Path] {
+				seenFile[f.Path] = true
+				lastTouchAuthor[f.Path] = c.Author
+			}
+
+			if authorCommitsPerFile[f.Path] == nil {
+				authorCommitsPerFile[f.Path] = make(map[string]int)
+			}
+			authorCommitsPerFile[f.Path][c.Author]++
+
+			if authorLastTimePerFile[f.Path] == nil {
+				authorLastTimePerFile[f.Path] = make(map[string]time.Time)
+			}
+			if _, ok := authorLastTimePerFile[f.Path][c.Author]; !ok {
+				authorLastTimePerFile[f.Path][c.Author] = c.AuthorTime
+			}
+
+			if c.AuthorTime.After(churnCutoff) {
+				churnCount[f.Path]++
+			}
+		}
+	}
+
+	rep := Report{
+		BusFactor:      busFactorByDirectory(lastTouchAuthor),
+		ChurnHotspots:  churnHotspots(churnCount, opts.TopN),
+		OwnershipDecay: ownershipDecay(authorCommitsPerFile, authorLastTimePerFile, opts.DecayThresholdDays, opts.TopN),
+	}
+
+	lifetimes, avg, err := branchLifetimes(backend)
+	if err != nil {
+		return Report{}, err
+	}
+	rep.BranchLifetimes = lifetimes
+	rep.AverageBranchLifetimeDays = avg
+
+	return rep, nil
+}
+
+func busFactorByDirectory(lastTouchAuthor map[string]string) []DirectoryBusFactor {
+	dirAuthorCounts := make(map[string]map[string]int)
+	for path, author := range lastTouchAuthor {
+		dir := filepath.Dir(path)
+		if dirAuthorCounts[dir] == nil {
+			dirAuthorCounts[dir] = make(map[string]int)
+		}
+		dirAuthorCounts[dir][author]++
+	}
+
+	result := make([]DirectoryBusFactor, 0, len(dirAuthorCounts))
+	for dir, counts := range dirAuthorCounts {
+		values := make([]int, 0, len(counts))
+		topAuthor, topCount, fileCount := "", 0, 0
+		for author, n := range counts {
+			values = append(values, n)
+			fileCount += n
+			if n > topCount {
+				topAuthor, topCount = author, n
+			}
+		}
+
+		result = append(result, DirectoryBusFactor{
+			Directory:   dir,
+			Gini:        gini(values),
+			TopAuthor:   topAuthor,
+			AuthorCount: len(counts),
+			FileCount:   fileCount,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Gini > result[j].Gini })
+	return result
+}
+
+func churnHotspots(churnCount map[string]int, topN int) []ChurnHotspot {
+	hotspots := make([]ChurnHotspot, 0, len(churnCount))
+	for path, n := range churnCount {
+		if n == 0 {
+			continue
+		}
+		hotspots = append(hotspots, ChurnHotspot{Path: path, CommitsInWindow: n})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool { return hotspots[i].CommitsInWindow > hotspots[j].CommitsInWindow })
+	if topN > 0 && len(hotspots) > topN {
+		hotspots = hotspots[:topN]
+	}
+	return hotspots
+}
+
+func ownershipDecay(
+	authorCommitsPerFile map[string]map[string]int,
+	authorLastTimePerFile map[string]map[string]time.Time,
+	thresholdDays, topN int,
+) []OwnershipDecay {
+	var decayed []OwnershipDecay
+
+	for path, counts := range authorCommitsPerFile {
+		topAuthor, topCount := "", 0
+		for author, n := range counts {
+			if n > topCount {
+				topAuthor, topCount = author, n
+			}
+		}
+		if topAuthor == "" {
+			continue
+		}
+
+		lastTime := authorLastTimePerFile[path][topAuthor]
+		daysSince := int(time.Since(lastTime).Hours() / 24)
+		if daysSince < thresholdDays {
+			continue
+		}
+
+		decayed = append(decayed, OwnershipDecay{
+			Path:                     path,
+			TopAuthor:                topAuthor,
+			DaysSinceTopAuthorCommit: daysSince,
+		})
+	}
+
+	sort.Slice(decayed, func(i, j int) bool {
+		return decayed[i].DaysSinceTopAuthorCommit > decayed[j].DaysSinceTopAuthorCommit
+	})
+	if topN > 0 && len(decayed) > topN {
+		decayed = decayed[:topN]
+	}
+	return decayed
+}
+
+// branchLifetimes approximates each branch's lifetime as the span between
+// where it diverged from the main branch and its last commit.
+func branchLifetimes(backend gitbackend.GitBackend) ([]BranchLifetime, float64, error) {
+	refs, err := backend.ListBranches()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mainBranch := findMainBranch(refs)
+	if mainBranch == "" {
+		return nil, 0, nil
+	}
+
+	var lifetimes []BranchLifetime
+	var totalDays, count float64
+
+	for _, ref := range refs {
+		if ref.Name == mainBranch {
+			continue
+		}
+
+		baseHash, err := backend.MergeBase(ref.Name, mainBranch)
+		if err != nil {
+			continue
+		}
+		baseTime, err := backend.CommitTime(baseHash)
+		if err != nil {
+			continue
+		}
+
+		span := ref.LastCommit.Sub(baseTime)
+		if span <= 0 {
+			continue
+		}
+
+		days := int(span.Hours() / 24)
+		lifetimes = append(lifetimes, BranchLifetime{Branch: ref.Name, Days: days})
+		totalDays += float64(days)
+		count++
+	}
+
+	sort.Slice(lifetimes, func(i, j int) bool { return lifetimes[i].Days > lifetimes[j].Days })
+
+	var avg float64
+	if count > 0 {
+		avg = totalDays / count
+	}
+	return lifetimes, avg, nil
+}
+
+func findMainBranch(refs []gitbackend.BranchRef) string {
+	for _, candidate := range []string{"main", "master", "develop"} {
+		for _, ref := range refs {
+			if ref.Name == candidate {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlWriter produces a single self-contained HTML file with sortable
+// tables, so results can be attached as a CI build artifact without any
+// external assets.
+type htmlWriter struct{}
+
+func (htmlWriter) Write(w io.Writer, rep Report) error {
+	return htmlTemplate.Execute(w, rep)
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>repo-analyze report</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem; color: #1a1a1a; }
+  h2 { margin-top: 2rem; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { border: 1px solid #ddd; padding: 0.4rem 0.6rem; text-align: left; }
+  th { background: #f4f4f4; cursor: pointer; user-select: none; }
+  tr:nth-child(even) { background: #fafafa; }
+</style>
+</head>
+<body>
+<h1>repo-analyze report{{if .RepoPath}}: {{.RepoPath}}{{end}}</h1>
+
+<h2>Stale branches ({{len .StaleBranches}})</h2>
+<table data-sortable>
+<thead><tr><th>Name</th><th>Remote</th><th>Last commit</th><th>Days stale</th><th>Author</th></tr></thead>
+<tbody>
+{{range .StaleBranches}}<tr><td>{{.Name}}</td><td>{{.IsRemote}}</td><td>{{.LastCommit.Format "2006-01-02"}}</td><td>{{.DaysStale}}</td><td>{{.Author}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Unmerged pull requests ({{len .UnmergedPRs}})</h2>
+<table data-sortable>
+<thead><tr><th>#</th><th>Title</th><th>Author</th><th>Branch</th><th>Local branch</th><th>Draft</th><th>Review</th><th>CI</th><th>Days since activity</th><th>No review stale</th><th>Failing checks</th><th>Awaiting author</th></tr></thead>
+<tbody>
+{{range .UnmergedPRs}}<tr><td>{{.Number}}</td><td>{{.Title}}</td><td>{{.Author}}</td><td>{{.HeadBranch}}</td><td>{{.LocalBranch}}</td><td>{{.Draft}}</td><td>{{.ReviewState}}</td><td>{{.CIStatus}}</td><td>{{.DaysSinceActivity}}</td><td>{{.NoReviewStale}}</td><td>{{.FailingChecks}}</td><td>{{.AwaitingAuthorResponse}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>TODO/FIXME comments ({{len .Todos}})</h2>
+<table data-sortable>
+<thead><tr><th>Type</th><th>File</th><th>Line</th><th>Days old</th><th>Assignee</th><th>Ticket</th><th>Content</th></tr></thead>
+<tbody>
+{{range .Todos}}<tr><td>{{.Type}}</td><td>{{.File}}</td><td>{{.Line}}</td><td>{{.DaysOld}}</td><td>{{.Assignee}}</td><td>{{if .Ticket}}{{.Ticket}} ({{if .TicketClosed}}closed{{else}}open{{end}}){{end}}</td><td>{{.Content}}</td></tr>
+{{end}}</tbody>
+</table>
+
+{{with .Metrics}}
+<h2>Bus factor by directory</h2>
+<table data-sortable>
+<thead><tr><th>Directory</th><th>Gini</th><th>Top author</th><th>Authors</th><th>Files</th></tr></thead>
+<tbody>
+{{range .BusFactor}}<tr><td>{{.Directory}}</td><td>{{printf "%.2f" .Gini}}</td><td>{{.TopAuthor}}</td><td>{{.AuthorCount}}</td><td>{{.FileCount}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Churn hotspots</h2>
+<table data-sortable>
+<thead><tr><th>File</th><th>Commits in window</th></tr></thead>
+<tbody>
+{{range .ChurnHotspots}}<tr><td>{{.Path}}</td><td>{{.CommitsInWindow}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Ownership decay</h2>
+<table data-sortable>
+<thead><tr><th>File</th><th>Top author</th><th>Days since their last commit</th></tr></thead>
+<tbody>
+{{range .OwnershipDecay}}<tr><td>{{.Path}}</td><td>{{.TopAuthor}}</td><td>{{.DaysSinceTopAuthorCommit}}</td></tr>
+{{end}}</tbody>
+</table>
+
+<h2>Branch lifetimes (average {{printf "%.1f" .AverageBranchLifetimeDays}} days)</h2>
+<table data-sortable>
+<thead><tr><th>Branch</th><th>Days</th></tr></thead>
+<tbody>
+{{range .BranchLifetimes}}<tr><td>{{.Branch}}</td><td>{{.Days}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}
+
+<script>
+// Minimal click-to-sort for any table marked data-sortable.
+document.querySelectorAll('table[data-sortable]').forEach(function (table) {
+  table.querySelectorAll('th').forEach(function (th, col) {
+    th.addEventListener('click', function () {
+      var tbody = table.querySelector('tbody');
+      var rows = Array.from(tbody.querySelectorAll('tr'));
+      var asc = th.dataset.asc !== 'true';
+      rows.sort(function (a, b) {
+        var av = a.children[col].innerText, bv = b.children[col].innerText;
+        var an = parseFloat(av), bn = parseFloat(bv);
+        var cmp = (!isNaN(an) && !isNaN(bn)) ? an - bn : av.localeCompare(bv);
+        return asc ? cmp : -cmp;
+      });
+      th.dataset.asc = asc;
+      rows.forEach(function (row) { tbody.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
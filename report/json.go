@@ -0,0 +1,14 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type jsonWriter struct{}
+
+func (jsonWriter) Write(w io.Writer, rep Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitWriter turns "stale beyond threshold" findings into JUnit test
+// failures, so CI pipelines can gate on them with their existing test
+// reporting. Branches and TODOs within threshold are reported as passing
+// testcases for visibility; PRs fail only when their checks are failing.
+type junitWriter struct{}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (junitWriter) Write(w io.Writer, rep Report) error {
+	suite := junitTestSuite{Name: "repo-analyze"}
+
+	for _, branch := range rep.StaleBranches {
+		tc := junitTestCase{
+			ClassName: "stale-branches",
+			Name:      branch.Name,
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("stale for %d days (threshold %d)", branch.DaysStale, rep.StaleDaysThreshold),
+				Text:    fmt.Sprintf("last commit by %s on %s", branch.Author, branch.LastCommit.Format("2006-01-02")),
+			},
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Failures++
+	}
+
+	for _, todo := range rep.Todos {
+		tc := junitTestCase{
+			ClassName: "old-todos",
+			Name:      fmt.Sprintf("%s:%d", todo.File, todo.Line),
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("%s is %d days old (threshold %d), assignee %s", todo.Type, todo.DaysOld, rep.TodoDaysThreshold, assigneeOrUnassigned(todo.Assignee)),
+				Text:    todo.Content,
+			},
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+		suite.Failures++
+	}
+
+	for _, pr := range rep.UnmergedPRs {
+		tc := junitTestCase{
+			ClassName: "unmerged-prs",
+			Name:      fmt.Sprintf("#%d %s", pr.Number, pr.Title),
+		}
+		if pr.FailingChecks {
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("CI status %s", pr.CIStatus),
+				Text:    fmt.Sprintf("review: %s, last activity %d days ago", pr.ReviewState, pr.DaysSinceActivity),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func assigneeOrUnassigned(assignee string) string {
+	if assignee == "" {
+		return "unassigned"
+	}
+	return assignee
+}
@@ -0,0 +1,111 @@
+// Package report turns analyzer findings into one of several output
+// formats so the results can be consumed by humans or by CI tooling.
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"repo-analyze/metrics"
+)
+
+// BranchFinding is a stale or unmerged branch flagged by an analyzer.
+type BranchFinding struct {
+	Name       string
+	IsRemote   bool
+	LastCommit time.Time
+	Author     string
+	DaysStale  int
+}
+
+// TodoFinding is a single old TODO/FIXME/XXX/HACK comment.
+type TodoFinding struct {
+	File       string
+	Line       int
+	Type       string
+	Content    string
+	DaysOld    int
+	CommitHash string
+
+	// Assignee is the explicit TODO(author) annotation if present,
+	// otherwise the blame author.
+	Assignee string
+	DueDate  time.Time
+	Ticket   string
+	Tracker  string
+
+	// TicketClosed is true when Ticket was looked up against its Tracker
+	// and found already resolved. Always false when no lookup was
+	// requested or the annotation carried no ticket.
+	TicketClosed bool
+}
+
+// PRFinding is an open pull/merge request, enriched with hosting-platform
+// state where a provider was available.
+type PRFinding struct {
+	Number            int
+	Title             string
+	Author            string
+	HeadBranch        string
+	HeadSHA           string
+	Draft             bool
+	ReviewState       string
+	CIStatus          string
+	DaysSinceActivity int
+
+	// LocalBranch is the name of the local branch whose tip matches
+	// HeadSHA, if any. Empty when no local branch is checked out for this
+	// PR (e.g. it was only ever pushed to the remote).
+	LocalBranch string
+
+	// NoReviewStale is true when the PR has gone without any review for
+	// longer than StaleDaysThreshold.
+	NoReviewStale bool
+
+	// FailingChecks is true when the PR's combined CI status is "failure".
+	FailingChecks bool
+
+	// AwaitingAuthorResponse is true when the ball is in the author's
+	// court: reviewers requested changes, or checks are failing.
+	AwaitingAuthorResponse bool
+}
+
+// Report is the complete set of findings from a single run, along with the
+// thresholds that were used to produce them.
+type Report struct {
+	RepoPath           string
+	StaleDaysThreshold int
+	TodoDaysThreshold  int
+
+	StaleBranches []BranchFinding
+	UnmergedPRs   []PRFinding
+	Todos         []TodoFinding
+
+	// Metrics is nil unless -metrics was requested.
+	Metrics *metrics.Report `json:",omitempty"`
+}
+
+// Writer renders a Report in a specific output format.
+type Writer interface {
+	Write(w io.Writer, rep Report) error
+}
+
+// NewWriter returns the Writer for the named format
+// (text, json, sarif, junit, or html).
+func NewWriter(format string) (Writer, error) {
+	switch format {
+	case "", "text":
+		return textWriter{}, nil
+	case "json":
+		return jsonWriter{}, nil
+	case "sarif":
+		return sarifWriter{}, nil
+	case "junit":
+		return junitWriter{}, nil
+	case "html":
+		return htmlWriter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want text, json, sarif, junit, or html)", format)
+	}
+}
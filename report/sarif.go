@@ -0,0 +1,120 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// sarifWriter emits TODO findings as a SARIF 2.1.0 log so GitHub code
+// scanning (and other SARIF consumers) can ingest them. Branch and PR
+// findings don't have a meaningful physicalLocation, so only TODOs are
+// represented.
+type sarifWriter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func (sarifWriter) Write(w io.Writer, rep Report) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "repo-analyze",
+						InformationURI: "https://github.com/herrero-code/repo-analyze",
+						Rules: []sarifRule{
+							{ID: "old-todo-comment", Name: "OldTodoComment"},
+						},
+					},
+				},
+				Results: todoResults(rep.Todos),
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func todoResults(todos []TodoFinding) []sarifResult {
+	results := make([]sarifResult, 0, len(todos))
+	for _, todo := range todos {
+		fingerprints := map[string]string{}
+		if todo.CommitHash != "" {
+			fingerprints["blameCommit/v1"] = todo.CommitHash
+		}
+
+		results = append(results, sarifResult{
+			RuleID: "old-todo-comment",
+			Level:  "warning",
+			Message: sarifMessage{
+				Text: todo.Type + ": " + todo.Content,
+			},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: todo.File},
+						Region:           sarifRegion{StartLine: todo.Line},
+					},
+				},
+			},
+			PartialFingerprints: fingerprints,
+		})
+	}
+	return results
+}
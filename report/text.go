@@ -0,0 +1,188 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"repo-analyze/metrics"
+)
+
+// textWriter reproduces the tool's original human-readable console output.
+type textWriter struct{}
+
+func (textWriter) Write(w io.Writer, rep Report) error {
+	writeBranchSection(w, rep)
+	writePRSection(w, rep)
+	writeTodoSection(w, rep)
+	if rep.Metrics != nil {
+		writeMetricsSection(w, *rep.Metrics)
+	}
+	return nil
+}
+
+func writeBranchSection(w io.Writer, rep Report) {
+	fmt.Fprintln(w, "📊 Analyzing Stale Branches")
+	fmt.Fprintln(w, "===========================")
+
+	if len(rep.StaleBranches) == 0 {
+		fmt.Fprintf(w, "✅ No stale branches found (older than %d days)\n\n", rep.StaleDaysThreshold)
+		return
+	}
+
+	branches := append([]BranchFinding(nil), rep.StaleBranches...)
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].DaysStale > branches[j].DaysStale
+	})
+
+	fmt.Fprintf(w, "Found %d stale branches:\n\n", len(branches))
+	for _, branch := range branches {
+		branchType := "local"
+		if branch.IsRemote {
+			branchType = "remote"
+		}
+
+		fmt.Fprintf(w, "🔸 %s (%s)\n", branch.Name, branchType)
+		fmt.Fprintf(w, "   Last commit: %s (%d days ago)\n",
+			branch.LastCommit.Format("2006-01-02"), branch.DaysStale)
+		fmt.Fprintf(w, "   Author: %s\n\n", branch.Author)
+	}
+}
+
+func writePRSection(w io.Writer, rep Report) {
+	fmt.Fprintln(w, "🔀 Analyzing Unmerged Pull Requests")
+	fmt.Fprintln(w, "===================================")
+
+	if len(rep.UnmergedPRs) == 0 {
+		fmt.Fprintln(w, "✅ No open pull requests found")
+		fmt.Fprintln(w)
+		return
+	}
+
+	var noReviewStale, failingChecks, awaitingAuthor int
+	for _, pr := range rep.UnmergedPRs {
+		if pr.NoReviewStale {
+			noReviewStale++
+		}
+		if pr.FailingChecks {
+			failingChecks++
+		}
+		if pr.AwaitingAuthorResponse {
+			awaitingAuthor++
+		}
+	}
+
+	fmt.Fprintf(w, "Found %d open pull requests (%d with no review in %d+ days, %d with failing checks, %d awaiting author response):\n\n",
+		len(rep.UnmergedPRs), noReviewStale, rep.StaleDaysThreshold, failingChecks, awaitingAuthor)
+	for _, pr := range rep.UnmergedPRs {
+		fmt.Fprintf(w, "🔸 #%d %s\n", pr.Number, pr.Title)
+		fmt.Fprintf(w, "   Branch: %s | Author: %s | Draft: %v\n", pr.HeadBranch, pr.Author, pr.Draft)
+		if pr.LocalBranch != "" {
+			fmt.Fprintf(w, "   Local branch: %s\n", pr.LocalBranch)
+		}
+		fmt.Fprintf(w, "   Review: %s | CI: %s\n", pr.ReviewState, pr.CIStatus)
+		if pr.NoReviewStale {
+			fmt.Fprintln(w, "   ⚠️  No review yet")
+		}
+		if pr.FailingChecks {
+			fmt.Fprintln(w, "   ⚠️  Failing checks")
+		}
+		if pr.AwaitingAuthorResponse {
+			fmt.Fprintln(w, "   ⚠️  Awaiting author response")
+		}
+		fmt.Fprintf(w, "   Last activity: %d days ago\n\n", pr.DaysSinceActivity)
+	}
+}
+
+func writeTodoSection(w io.Writer, rep Report) {
+	fmt.Fprintln(w, "📝 Analyzing TODO/FIXME Comments")
+	fmt.Fprintln(w, "================================")
+
+	if len(rep.Todos) == 0 {
+		fmt.Fprintf(w, "✅ No old TODO/FIXME comments found (older than %d days)\n\n", rep.TodoDaysThreshold)
+		return
+	}
+
+	todos := append([]TodoFinding(nil), rep.Todos...)
+
+	todoCount, fixmeCount := 0, 0
+	for _, todo := range todos {
+		switch strings.ToUpper(todo.Type) {
+		case "TODO":
+			todoCount++
+		case "FIXME":
+			fixmeCount++
+		}
+	}
+
+	fmt.Fprintf(w, "Found %d old comments (%d TODOs, %d FIXMEs):\n\n", len(todos), todoCount, fixmeCount)
+
+	byAssignee := make(map[string][]TodoFinding)
+	for _, todo := range todos {
+		assignee := todo.Assignee
+		if assignee == "" {
+			assignee = "unassigned"
+		}
+		byAssignee[assignee] = append(byAssignee[assignee], todo)
+	}
+
+	assignees := make([]string, 0, len(byAssignee))
+	for assignee := range byAssignee {
+		assignees = append(assignees, assignee)
+	}
+	sort.Slice(assignees, func(i, j int) bool {
+		return len(byAssignee[assignees[i]]) > len(byAssignee[assignees[j]])
+	})
+
+	for _, assignee := range assignees {
+		group := byAssignee[assignee]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].DaysOld > group[j].DaysOld
+		})
+
+		fmt.Fprintf(w, "👤 %s (%d)\n", assignee, len(group))
+		for _, todo := range group {
+			fmt.Fprintf(w, "🔸 %s (%d days old)\n", todo.Type, todo.DaysOld)
+			fmt.Fprintf(w, "   File: %s:%d\n", todo.File, todo.Line)
+			fmt.Fprintf(w, "   Content: %s\n", strings.TrimSpace(todo.Content))
+			if todo.Ticket != "" {
+				status := "open"
+				if todo.TicketClosed {
+					status = "closed"
+				}
+				fmt.Fprintf(w, "   Ticket: %s (%s, %s)\n", todo.Ticket, todo.Tracker, status)
+			}
+			if !todo.DueDate.IsZero() {
+				fmt.Fprintf(w, "   Due: %s\n", todo.DueDate.Format("2006-01-02"))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func writeMetricsSection(w io.Writer, m metrics.Report) {
+	fmt.Fprintln(w, "📈 Repo Health Metrics")
+	fmt.Fprintln(w, "======================")
+
+	fmt.Fprintln(w, "Bus factor by directory (higher Gini = more concentrated ownership):")
+	for _, bf := range m.BusFactor {
+		fmt.Fprintf(w, "🔸 %s: gini=%.2f top-author=%s (%d/%d files)\n",
+			bf.Directory, bf.Gini, bf.TopAuthor, bf.AuthorCount, bf.FileCount)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Churn hotspots:")
+	for _, h := range m.ChurnHotspots {
+		fmt.Fprintf(w, "🔸 %s: %d commits\n", h.Path, h.CommitsInWindow)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Ownership decay:")
+	for _, d := range m.OwnershipDecay {
+		fmt.Fprintf(w, "🔸 %s: top author %s, last touched %d days ago\n", d.Path, d.TopAuthor, d.DaysSinceTopAuthorCommit)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Average branch lifetime: %.1f days\n\n", m.AverageBranchLifetimeDays)
+}
@@ -0,0 +1,117 @@
+// Package todocache persists TODO scan results across runs so repeated
+// invocations only re-blame the files that actually changed.
+package todocache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// HashLine fingerprints a line's content for use as part of a cache key.
+func HashLine(content string) string {
+	return HashContent([]byte(content))
+}
+
+// HashContent fingerprints a file's working-tree bytes for use as a cache
+// key, so an uncommitted edit always invalidates the cache even though it
+// has no blob SHA of its own yet.
+func HashContent(data []byte) string {
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Record is one matched TODO/FIXME/etc comment, with the blame data already
+// resolved so it doesn't need to be looked up again.
+type Record struct {
+	Line          int       `json:"line"`
+	LineHash      string    `json:"line_hash"`
+	Type          string    `json:"type"`
+	Content       string    `json:"content"`
+	CommitterTime time.Time `json:"committer_time"`
+	CommitHash    string    `json:"commit_hash"`
+
+	// Author, DueDate, Ticket, and Tracker come from an explicit
+	// annotation on the comment, e.g. TODO(alice) or FIXME[JIRA-456].
+	Author  string    `json:"author,omitempty"`
+	DueDate time.Time `json:"due_date,omitempty"`
+	Ticket  string    `json:"ticket,omitempty"`
+	Tracker string    `json:"tracker,omitempty"`
+}
+
+// FileEntry caches the scan result for a single file as of a specific blob.
+type FileEntry struct {
+	BlobSHA string   `json:"blob_sha"`
+	Records []Record `json:"records"`
+}
+
+// Cache is the on-disk cache format, keyed by repo-relative file path.
+type Cache struct {
+	HeadCommit string               `json:"head_commit"`
+	Files      map[string]FileEntry `json:"files"`
+}
+
+// Load reads the cache file at path. A missing file is not an error; it
+// returns an empty, usable Cache so the first run behaves like a full scan.
+func Load(path string) (*Cache, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Cache{Files: make(map[string]FileEntry)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cache Cache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	if cache.Files == nil {
+		cache.Files = make(map[string]FileEntry)
+	}
+	return &cache, nil
+}
+
+// Save writes the cache to path as indented JSON.
+func (c *Cache) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Lookup returns the cached entry for file if its blob hasn't changed since
+// the entry was recorded.
+func (c *Cache) Lookup(file, blobSHA string) (FileEntry, bool) {
+	entry, ok := c.Files[file]
+	if !ok || entry.BlobSHA != blobSHA {
+		return FileEntry{}, false
+	}
+	return entry, true
+}
+
+// LookupLine returns the cached record for file whose line content hash
+// matches lineHash, even if the file's blob has changed since it was
+// cached. A line's content hash surviving a file-wide change means that
+// particular comment is untouched, so its already-resolved blame data
+// (the expensive part of a scan) can be reused instead of re-blamed.
+func (c *Cache) LookupLine(file, lineHash string) (Record, bool) {
+	entry, ok := c.Files[file]
+	if !ok {
+		return Record{}, false
+	}
+	for _, rec := range entry.Records {
+		if rec.LineHash == lineHash {
+			return rec, true
+		}
+	}
+	return Record{}, false
+}
+
+// Store replaces the cached entry for file.
+func (c *Cache) Store(file, blobSHA string, records []Record) {
+	c.Files[file] = FileEntry{BlobSHA: blobSHA, Records: records}
+}
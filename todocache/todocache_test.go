@@ -0,0 +1,35 @@
+package todocache
+
+import "testing"
+
+func TestLookupRequiresMatchingBlobSHA(t *testing.T) {
+	c := &Cache{Files: make(map[string]FileEntry)}
+	c.Store("main.go", "blob-a", []Record{{Line: 1, LineHash: HashLine("// TODO: x")}})
+
+	if _, ok := c.Lookup("main.go", "blob-b"); ok {
+		t.Error("Lookup matched a stale blob SHA")
+	}
+	if _, ok := c.Lookup("main.go", "blob-a"); !ok {
+		t.Error("Lookup missed a fresh blob SHA")
+	}
+}
+
+func TestLookupLineSurvivesBlobChange(t *testing.T) {
+	c := &Cache{Files: make(map[string]FileEntry)}
+	unchanged := Record{Line: 5, LineHash: HashLine("// TODO: still here"), CommitHash: "abc123"}
+	c.Store("main.go", "blob-a", []Record{unchanged})
+
+	// The file's blob changed (some other line edited), but this comment's
+	// content, and therefore its line hash, did not.
+	rec, ok := c.LookupLine("main.go", HashLine("// TODO: still here"))
+	if !ok {
+		t.Fatal("LookupLine missed a record whose content hash is unchanged")
+	}
+	if rec.CommitHash != "abc123" {
+		t.Errorf("CommitHash = %q, want %q", rec.CommitHash, "abc123")
+	}
+
+	if _, ok := c.LookupLine("main.go", HashLine("// TODO: brand new")); ok {
+		t.Error("LookupLine matched a line hash that was never cached")
+	}
+}
@@ -0,0 +1,73 @@
+package todoparse
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Marker is a single parsed TODO/FIXME/XXX/HACK marker found in a comment.
+type Marker struct {
+	Type    string // TODO, FIXME, XXX, HACK
+	Message string
+
+	// Author, DueDate, and Ticket are populated only when the comment
+	// explicitly annotates them, e.g. TODO(alice), TODO(2024-06-01),
+	// FIXME[JIRA-456], TODO(#123). They override blame-derived data when
+	// present.
+	Author  string
+	DueDate time.Time
+
+	// Ticket and Tracker are set together: Tracker is "jira", "github", or
+	// "" if Ticket doesn't look like either.
+	Ticket  string
+	Tracker string
+}
+
+// markerRegex matches TYPE, optional (...)/[...] annotation, optional
+// ":"/"-" separator, then the rest of the comment as the message.
+var markerRegex = regexp.MustCompile(`(?i)\b(TODO|FIXME|XXX|HACK)\s*(?:[(\[]([^)\]]*)[)\]])?\s*[:\-]?\s*(.*)`)
+
+var (
+	dateRe   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	githubRe = regexp.MustCompile(`^#?\d+$`)
+	jiraRe   = regexp.MustCompile(`^[A-Z][A-Z0-9]+-\d+$`)
+)
+
+// ParseMarker looks for a TODO-style marker in a comment's text and parses
+// any structured annotation it carries. It returns ok=false if the comment
+// doesn't contain one.
+func ParseMarker(commentText string) (Marker, bool) {
+	matches := markerRegex.FindStringSubmatch(commentText)
+	if matches == nil {
+		return Marker{}, false
+	}
+
+	m := Marker{
+		Type:    strings.ToUpper(matches[1]),
+		Message: strings.TrimSpace(matches[3]),
+	}
+	if m.Message == "" {
+		m.Message = strings.TrimSpace(commentText)
+	}
+
+	annotation := strings.TrimSpace(matches[2])
+	switch {
+	case annotation == "":
+		// no structured annotation
+	case dateRe.MatchString(annotation):
+		if t, err := time.Parse("2006-01-02", annotation); err == nil {
+			m.DueDate = t
+		}
+	case jiraRe.MatchString(strings.ToUpper(annotation)):
+		m.Ticket = strings.ToUpper(annotation)
+		m.Tracker = "jira"
+	case githubRe.MatchString(annotation):
+		m.Ticket = strings.TrimPrefix(annotation, "#")
+		m.Tracker = "github"
+	default:
+		m.Author = annotation
+	}
+
+	return m, true
+}
@@ -0,0 +1,182 @@
+// Package todoparse extracts TODO/FIXME/XXX/HACK markers from real
+// comments - not from string literals - and parses the structured
+// annotations authors attach to them (explicit author, ticket reference,
+// due date).
+package todoparse
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Comment is a single comment found in a source file, with the line it
+// starts on. Multi-line block comments are split into one Comment per
+// physical line so callers can still map a match back to a blame-able
+// line number.
+type Comment struct {
+	Line int
+	Text string
+}
+
+// dialect describes how a language spells line and block comments.
+type dialect struct {
+	line       string // e.g. "//" or "#"; empty if the language has none
+	blockStart string // e.g. "/*"; empty if the language has no block comments
+	blockEnd   string // e.g. "*/"
+	quotes     string // characters that open/close string literals, e.g. `"'`+"`"
+}
+
+var extensionDialects = map[string]dialect{
+	".go":   {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`"},
+	".c":    {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".h":    {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".cc":   {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".cpp":  {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".hpp":  {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".java": {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".js":   {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`"},
+	".jsx":  {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`"},
+	".ts":   {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`"},
+	".tsx":  {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'`"},
+	".rs":   {line: "//", blockStart: "/*", blockEnd: "*/", quotes: "\"'"},
+	".py":   {line: "#", quotes: "\"'"},
+	".rb":   {line: "#", quotes: "\"'"},
+	".sh":   {line: "#", quotes: "\"'"},
+	".bash": {line: "#", quotes: "\"'"},
+}
+
+// defaultDialect is used for unrecognized extensions: treat '#' and '//' as
+// comment starts (whichever appears outside a string first), which covers
+// most config and script formats reasonably well.
+var defaultDialect = dialect{line: "#", quotes: "\"'"}
+
+func dialectFor(path string) dialect {
+	if d, ok := extensionDialects[strings.ToLower(filepath.Ext(path))]; ok {
+		return d
+	}
+	return defaultDialect
+}
+
+// ExtractComments scans r line by line and returns every comment found,
+// skipping text inside string literals so a TODO mentioned in a string
+// doesn't get flagged as a real one.
+func ExtractComments(path string, r io.Reader) ([]Comment, error) {
+	d := dialectFor(path)
+
+	var comments []Comment
+	inBlock := false
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if inBlock {
+			if d.blockEnd == "" {
+				inBlock = false
+				continue
+			}
+			if idx := strings.Index(line, d.blockEnd); idx >= 0 {
+				comments = append(comments, Comment{Line: lineNum, Text: line[:idx]})
+				inBlock = false
+				rest := line[idx+len(d.blockEnd):]
+				if c, ok, open := scanRestOfLine(rest, lineNum, d); ok {
+					comments = append(comments, c)
+					inBlock = open
+				}
+				continue
+			}
+			comments = append(comments, Comment{Line: lineNum, Text: line})
+			continue
+		}
+
+		if c, ok, open := scanRestOfLine(line, lineNum, d); ok {
+			comments = append(comments, c)
+			inBlock = open
+		} else if started, text := blockStartsOnLine(line, d); started {
+			comments = append(comments, Comment{Line: lineNum, Text: text})
+			inBlock = true
+		}
+	}
+
+	return comments, scanner.Err()
+}
+
+// maskStrings returns line with the contents of every string literal
+// (as delimited by quotes) blanked out, so a caller can search the result
+// for comment markers with a plain substring search without matching text
+// that only looks like a comment because it's sitting inside a string.
+// Quote characters themselves are left in place; only what's between them
+// is blanked, so positions line up with the original line.
+func maskStrings(line string, quotes string) string {
+	if quotes == "" {
+		return line
+	}
+	masked := []byte(line)
+	var inString byte
+	for i := 0; i < len(masked); i++ {
+		ch := masked[i]
+		if inString != 0 {
+			if ch == '\\' {
+				if i+1 < len(masked) {
+					masked[i+1] = ' '
+				}
+				i++ // skip escaped character
+				continue
+			}
+			if ch == inString {
+				inString = 0
+				continue
+			}
+			masked[i] = ' '
+			continue
+		}
+		if strings.IndexByte(quotes, ch) >= 0 {
+			inString = ch
+		}
+	}
+	return string(masked)
+}
+
+// scanRestOfLine walks a line outside of a block comment, tracking string
+// literal state, and returns the first comment found on it (if any). The
+// third return value reports whether that comment is a block comment left
+// unterminated on this line, so the caller should keep scanning in block
+// mode on subsequent lines.
+func scanRestOfLine(line string, lineNum int, d dialect) (Comment, bool, bool) {
+	masked := maskStrings(line, d.quotes)
+	for i := 0; i < len(line); i++ {
+		if d.line != "" && strings.HasPrefix(masked[i:], d.line) {
+			return Comment{Line: lineNum, Text: line[i+len(d.line):]}, true, false
+		}
+		if d.blockStart != "" && strings.HasPrefix(masked[i:], d.blockStart) {
+			rest := line[i+len(d.blockStart):]
+			if d.blockEnd != "" {
+				if end := strings.Index(rest, d.blockEnd); end >= 0 {
+					return Comment{Line: lineNum, Text: rest[:end]}, true, false
+				}
+			}
+			return Comment{Line: lineNum, Text: rest}, true, d.blockEnd != ""
+		}
+	}
+	return Comment{}, false, false
+}
+
+// blockStartsOnLine reports whether an (unterminated) block comment opens
+// on this line, for the caller to continue scanning in block mode. It
+// shares scanRestOfLine's string-aware masking so a block-comment-looking
+// sequence inside a string literal isn't mistaken for a real one.
+func blockStartsOnLine(line string, d dialect) (bool, string) {
+	if d.blockStart == "" {
+		return false, ""
+	}
+	masked := maskStrings(line, d.quotes)
+	idx := strings.Index(masked, d.blockStart)
+	if idx < 0 {
+		return false, ""
+	}
+	return true, line[idx+len(d.blockStart):]
+}
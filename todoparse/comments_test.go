@@ -0,0 +1,57 @@
+package todoparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractCommentsSkipsStringLiterals(t *testing.T) {
+	src := `package main
+
+func main() {
+	fmt.Println("see /docs/* for details")
+	// TODO: real comment
+}
+`
+	comments, err := ExtractComments("main.go", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ExtractComments: %v", err)
+	}
+
+	if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1: %+v", len(comments), comments)
+	}
+	if got := strings.TrimSpace(comments[0].Text); got != "TODO: real comment" {
+		t.Errorf("comment text = %q, want %q", got, "TODO: real comment")
+	}
+	if comments[0].Line != 5 {
+		t.Errorf("comment line = %d, want 5", comments[0].Line)
+	}
+}
+
+func TestExtractCommentsBlockComment(t *testing.T) {
+	src := `package main
+
+/* TODO: fix this
+   spans multiple lines */
+func main() {}
+`
+	comments, err := ExtractComments("main.go", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ExtractComments: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2: %+v", len(comments), comments)
+	}
+	if !strings.Contains(comments[0].Text, "TODO: fix this") {
+		t.Errorf("comments[0].Text = %q", comments[0].Text)
+	}
+}
+
+func TestBlockStartsOnLineIgnoresStringLiterals(t *testing.T) {
+	d := extensionDialects[".go"]
+	started, _ := blockStartsOnLine(`fmt.Println("see /docs/* for details")`, d)
+	if started {
+		t.Error("blockStartsOnLine reported a block comment opening inside a string literal")
+	}
+}
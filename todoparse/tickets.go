@@ -0,0 +1,59 @@
+package todoparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var ticketHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// GitHubIssueClosed reports whether issue/PR number in owner/repo is closed.
+func GitHubIssueClosed(owner, repo, number string) (bool, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/issues/%s", owner, repo, number)
+	resp, err := ticketHTTPClient.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("github API returned %s for issue %s", resp.Status, number)
+	}
+
+	var issue struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return false, err
+	}
+	return issue.State == "closed", nil
+}
+
+// JiraIssueClosed reports whether ticket is resolved/closed on the JIRA
+// instance at baseURL (e.g. "https://yourcompany.atlassian.net").
+func JiraIssueClosed(baseURL, ticket string) (bool, error) {
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=status", baseURL, ticket)
+	resp, err := ticketHTTPClient.Get(url)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("jira API returned %s for issue %s", resp.Status, ticket)
+	}
+
+	var payload struct {
+		Fields struct {
+			Status struct {
+				StatusCategory struct {
+					Key string `json:"key"` // "done", "indeterminate", "new"
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return false, err
+	}
+	return payload.Fields.Status.StatusCategory.Key == "done", nil
+}